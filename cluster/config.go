@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -14,6 +15,10 @@ type Config struct {
 	Address                                      string
 	ClusterProvider                              ClusterProvider
 	IdentityLookup                               IdentityLookup
+	Auth                                         Auth
+	Leadership                                   Leadership
+	TSOEnabled                                   bool
+	HealthCheckEnabled                           bool
 	RemoteConfig                                 *remote.Config
 	RequestTimeoutTime                           time.Duration
 	RequestsLogThrottlePeriod                    time.Duration
@@ -36,6 +41,7 @@ func Configure(clusterName string, clusterProvider ClusterProvider, identityLook
 		Name:                      clusterName,
 		ClusterProvider:           clusterProvider,
 		IdentityLookup:            identityLookup,
+		Auth:                      noopAuth{},
 		RequestTimeoutTime:        defaultActorRequestTimeout,
 		RequestsLogThrottlePeriod: defaultRequestsLogThrottlePeriod,
 		MemberStrategyBuilder:     newDefaultMemberStrategy,
@@ -85,6 +91,16 @@ func WithMaxNumberOfEventsInRequestLogThrottlePeriod(maxNumber int) ConfigOption
 	}
 }
 
+// WithAuth sets the Auth implementation used to verify tokens carried on
+// incoming grain requests and to mint the token Cluster.Call attaches to
+// outgoing ones. Requests sent through ClusterContext.Request directly
+// carry no token. Defaults to a noopAuth that accepts everything.
+func WithAuth(auth Auth) ConfigOption {
+	return func(c *Config) {
+		c.Auth = auth
+	}
+}
+
 func WithKinds(kinds ...*Kind) ConfigOption {
 	return func(c *Config) {
 		for _, kind := range kinds {
@@ -110,6 +126,7 @@ type Kind struct {
 	Kind            string
 	Props           *actor.Props
 	StrategyBuilder func(*Cluster) MemberStrategy
+	Singleton       bool
 }
 
 // Creates a new instance of a kind
@@ -143,6 +160,9 @@ func withClusterReceiveMiddleware() actor.PropsOption {
 			case *actor.Stopped:
 				handleStopped(c, next, envelope)
 			default:
+				if !authorize(c, next, envelope) {
+					return
+				}
 				next(c, envelope)
 			}
 
@@ -165,6 +185,7 @@ func handleStopped(c actor.ReceiverContext, next actor.ReceiverFunc, envelope *a
 			ClusterIdentity: identity,
 		})
 		cl.PidCache.RemoveByValue(identity.Identity, identity.Kind, c.Self())
+		forgetSingletonActivation(cl, identity.Kind, c.Self())
 	}
 
 	next(c, envelope)
@@ -182,6 +203,79 @@ func handleStarted(c actor.ReceiverContext, next actor.ReceiverFunc, envelope *a
 
 	ge := actor.WrapEnvelope(grainInit)
 	next(c, ge)
+
+	if ak := cl.GetClusterKind(identity.Kind); ak != nil && ak.Singleton {
+		rememberSingletonActivation(cl, identity.Kind, c.Self())
+	}
+}
+
+// authorize verifies the token carried on an incoming grain envelope
+// before it reaches the actor's Receive. The token travels as an
+// *AuthEnvelope wrapping the real payload (see Cluster.Call), which
+// authorize unwraps back to the plain message on success. When
+// verification fails, the envelope is dropped, an AuthenticationFailed
+// event is published for local diagnostics, and - crucially - the same
+// AuthenticationFailed is sent back to envelope.Sender so the caller gets
+// a typed rejection instead of silently timing out.
+//
+// Only Cluster.Call attaches a token, by wrapping the outbound message in
+// an AuthEnvelope. A message a grain sends itself or another local actor
+// directly - ctx.Send, a timer firing - never goes through Cluster.Call,
+// so it arrives here unwrapped and has no token to check; authorize lets
+// those through unauthenticated rather than rejecting every local send
+// once Auth is configured, since Auth exists to gate cross-member grain
+// requests, not same-process actor messaging.
+func authorize(c actor.ReceiverContext, next actor.ReceiverFunc, envelope *actor.MessageEnvelope) bool {
+	cl := GetCluster(c.ActorSystem())
+	if cl == nil || cl.Config == nil || cl.Config.Auth == nil {
+		return true
+	}
+	if _, ok := cl.Config.Auth.(noopAuth); ok {
+		return true
+	}
+
+	env, wrapped := envelope.Message.(*AuthEnvelope)
+	if !wrapped {
+		return true
+	}
+
+	claims, err := cl.Config.Auth.Verify(&Token{raw: env.rawToken()})
+	if err != nil {
+		failure := &AuthenticationFailed{Pid: c.Self(), Reason: err}
+		cl.ActorSystem.EventStream.Publish(failure)
+		if envelope.Sender != nil {
+			cl.ActorSystem.Root.Send(envelope.Sender, failure)
+		}
+		return false
+	}
+
+	inner, err := env.unwrapPayload()
+	if err != nil {
+		failure := &AuthenticationFailed{Pid: c.Self(), Reason: err}
+		cl.ActorSystem.EventStream.Publish(failure)
+		if envelope.Sender != nil {
+			cl.ActorSystem.Root.Send(envelope.Sender, failure)
+		}
+		return false
+	}
+	envelope.Message = inner
+
+	//inject the verified claims into the actor context, the same way
+	//ClusterInit is injected in handleStarted
+	next(c, actor.WrapEnvelope(claims))
+	return true
+}
+
+// AuthenticationFailed is published on the ActorSystem's EventStream when a
+// grain invocation is rejected by authorize, so callers and diagnostics can
+// observe the rejection without a bespoke error-response channel.
+type AuthenticationFailed struct {
+	Pid    *actor.PID
+	Reason error
+}
+
+func (e *AuthenticationFailed) Error() string {
+	return "cluster: authentication failed: " + e.Reason.Error()
 }
 
 func (k *Kind) WithMemberStrategy(strategyBuilder func(*Cluster) MemberStrategy) {
@@ -196,23 +290,73 @@ func (k *Kind) Build(cluster *Cluster) *ActivatedKind {
 	}
 
 	return &ActivatedKind{
-		Kind:     k.Kind,
-		Props:    k.Props,
-		Strategy: strategy,
+		Kind:      k.Kind,
+		Props:     k.Props,
+		Strategy:  strategy,
+		Singleton: k.Singleton,
 	}
 }
 
 type ActivatedKind struct {
-	Kind     string
-	Props    *actor.Props
-	Strategy MemberStrategy
-	count    int32
+	Kind      string
+	Props     *actor.Props
+	Strategy  MemberStrategy
+	Singleton bool
+	count     int32
 }
 
+// Inc records a new activation. For a Singleton kind this is a no-op past
+// the first activation, so count reflects the at-most-one-live invariant
+// NewSingletonKind guarantees instead of drifting with races between the
+// outgoing leader's teardown and the new leader's first activation.
 func (ak *ActivatedKind) Inc() {
+	if ak.Singleton && atomic.LoadInt32(&ak.count) >= 1 {
+		return
+	}
 	atomic.AddInt32(&ak.count, 1)
 }
 
 func (ak *ActivatedKind) Dev() {
 	atomic.AddInt32(&ak.count, -1)
 }
+
+// singletonActivationKey scopes a tracked activation to both the Cluster
+// instance and the kind name. Keying by kind alone would let two Cluster
+// instances sharing a kind name in the same process - this repo's own
+// tests routinely run several Cluster/ActorSystem instances in one
+// process to simulate a multi-member cluster - clobber each other's
+// tracked activation, so poisonActivationsOfKind could poison the wrong
+// cluster's actor.
+type singletonActivationKey struct {
+	cluster *Cluster
+	kind    string
+}
+
+// singletonActivations tracks the local PID currently activated for each
+// (Cluster, Singleton kind) pair, so a leader that loses its lease can
+// poison its own activation without waiting on a cluster-wide
+// deactivation round trip.
+var singletonActivations sync.Map // singletonActivationKey -> *actor.PID
+
+func rememberSingletonActivation(cluster *Cluster, kind string, pid *actor.PID) {
+	singletonActivations.Store(singletonActivationKey{cluster, kind}, pid)
+}
+
+func forgetSingletonActivation(cluster *Cluster, kind string, pid *actor.PID) {
+	key := singletonActivationKey{cluster, kind}
+	if existing, ok := singletonActivations.Load(key); ok && existing.(*actor.PID).Equal(pid) {
+		singletonActivations.Delete(key)
+	}
+}
+
+// poisonActivationsOfKind is called by singletonMemberStrategy when this
+// member loses leadership of a singleton kind's election, so the local
+// activation (if any) tears down immediately rather than lingering until
+// the new leader's placement evicts it.
+func (c *Cluster) poisonActivationsOfKind(kind string) {
+	pid, ok := singletonActivations.Load(singletonActivationKey{c, kind})
+	if !ok {
+		return
+	}
+	c.ActorSystem.Root.Poison(pid.(*actor.PID))
+}
@@ -0,0 +1,95 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+// defaultLeadershipTTLSeconds is the etcd lease TTL backing a Leader's
+// session: short enough that a crashed leader's activation is reclaimed
+// quickly, long enough to ride out a gossip hiccup.
+const defaultLeadershipTTLSeconds = 5
+
+// NewEtcdLeadership returns a Leadership backed by etcd leases and the
+// concurrency package's leader election, parallel to the etcd
+// ClusterProvider.
+func NewEtcdLeadership(client *clientv3.Client, memberID string) Leadership {
+	return &etcdLeadership{client: client, memberID: memberID}
+}
+
+type etcdLeadership struct {
+	client   *clientv3.Client
+	memberID string
+}
+
+func (l *etcdLeadership) Campaign(ctx context.Context, key string) (Leader, error) {
+	session, err := concurrency.NewSession(l.client, concurrency.WithTTL(defaultLeadershipTTLSeconds))
+	if err != nil {
+		return nil, err
+	}
+
+	election := concurrency.NewElection(session, key)
+	if err := election.Campaign(ctx, l.memberID); err != nil {
+		session.Close()
+		return nil, err
+	}
+
+	leader := &etcdLeader{
+		memberID: l.memberID,
+		session:  session,
+		election: election,
+		done:     make(chan struct{}),
+	}
+	go leader.watchKeepalive()
+
+	return leader, nil
+}
+
+// Resign does not give up leadership: etcdLeadership keeps no record of
+// the Leader handles Campaign returns, so it always returns an error.
+// Leadership held through etcd is given up by the Leader handle's Resign.
+func (l *etcdLeadership) Resign(key string) error {
+	return errors.New("cluster: Resign must be called on the Leader handle returned by Campaign")
+}
+
+func (l *etcdLeadership) Leader(key string) (string, bool) {
+	session, err := concurrency.NewSession(l.client, concurrency.WithTTL(defaultLeadershipTTLSeconds))
+	if err != nil {
+		return "", false
+	}
+	defer session.Close()
+
+	resp, err := concurrency.NewElection(session, key).Leader(context.Background())
+	if err != nil || len(resp.Kvs) == 0 {
+		return "", false
+	}
+	return string(resp.Kvs[0].Value), true
+}
+
+// etcdLeader is a held leadership lease. watchKeepalive watches the
+// session's own keepalive channel and, the instant it closes (lease lost
+// or the client is shutting down), closes done so the holder stops acting
+// as leader before the TTL actually expires on etcd's side.
+type etcdLeader struct {
+	memberID string
+	session  *concurrency.Session
+	election *concurrency.Election
+	done     chan struct{}
+}
+
+func (l *etcdLeader) MemberID() string { return l.memberID }
+
+func (l *etcdLeader) Done() <-chan struct{} { return l.done }
+
+func (l *etcdLeader) Resign() error {
+	defer l.session.Close()
+	return l.election.Resign(context.Background())
+}
+
+func (l *etcdLeader) watchKeepalive() {
+	<-l.session.Done()
+	close(l.done)
+}
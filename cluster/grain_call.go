@@ -0,0 +1,271 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrStreamClosed is returned by a stream-side Send/Recv after the peer or
+// ctx has closed the stream.
+var ErrStreamClosed = errors.New("cluster: stream closed")
+
+// memberTokens caches the token each *Cluster generates for itself, keyed
+// by the Cluster's own pointer identity rather than a name string, so
+// multiple Cluster instances sharing a name in the same process (as this
+// repo's own multi-member-in-one-process tests do) never collide.
+var memberTokens sync.Map // *Cluster -> *Token
+
+// currentToken returns the token this member attaches to its own
+// outbound grain requests, generating and caching one lazily the first
+// time it's needed. Returns nil when Auth is unset or noop, so Call can
+// skip wrapping entirely.
+func (c *Cluster) currentToken() (*Token, error) {
+	if c.Config.Auth == nil {
+		return nil, nil
+	}
+	if _, ok := c.Config.Auth.(noopAuth); ok {
+		return nil, nil
+	}
+
+	if t, ok := memberTokens.Load(c); ok {
+		return t.(*Token), nil
+	}
+
+	t, err := c.Config.Auth.Generate(c.ActorSystem.Address())
+	if err != nil {
+		return nil, err
+	}
+	memberTokens.Store(c, t)
+	return t, nil
+}
+
+// Call performs a unary grain request against identity/kind, attaching
+// the caller's current Auth token (if any) so the receiving member's
+// authorize() middleware can verify it. When a token is attached, message
+// must be a proto.Message: the wrapping AuthEnvelope has to survive the
+// cross-member gRPC hop clusterCtx.Request takes when the target grain is
+// activated on a different member, and a bare Go struct can't.
+func (c *Cluster) Call(identity, kind string, message interface{}) (interface{}, error) {
+	token, err := c.currentToken()
+	if err != nil {
+		return nil, err
+	}
+	if token != nil {
+		protoMessage, ok := message.(proto.Message)
+		if !ok {
+			return nil, fmt.Errorf("cluster: Call message %T is not a proto.Message, required once Auth is configured", message)
+		}
+		env, err := newAuthEnvelope(token.raw, protoMessage)
+		if err != nil {
+			return nil, err
+		}
+		message = env
+	}
+
+	clusterCtx := c.Config.ClusterContextProducer(c)
+	return clusterCtx.Request(identity, kind, message)
+}
+
+// ClientStream is a bidi/client-streaming handle backing the generated
+// *Stream types: Send pushes onto a bounded channel (backpressure once
+// full) and a goroutine drains it into the grain via repeated unary
+// requests scoped to this call's identity, stopping as soon as ctx is
+// done or the grain ends the stream.
+type ClientStream struct {
+	ctx      context.Context
+	cluster  *Cluster
+	identity string
+	kind     string
+	method   string
+
+	send chan interface{}
+	recv chan interface{}
+	done chan struct{}
+}
+
+func (c *Cluster) newClientStream(ctx context.Context, identity, kind, method string, clientBuf, serverBuf int32) *ClientStream {
+	s := &ClientStream{
+		ctx:      ctx,
+		cluster:  c,
+		identity: identity,
+		kind:     kind,
+		method:   method,
+		send:     make(chan interface{}, clientBuf),
+		recv:     make(chan interface{}, serverBuf),
+		done:     make(chan struct{}),
+	}
+	go s.pump()
+	return s
+}
+
+// pump forwards every message enqueued via Send to the grain as a
+// StreamFrame, and fans the grain's replies back out on recv, until ctx is
+// cancelled or the grain closes the stream.
+func (s *ClientStream) pump() {
+	defer close(s.done)
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case msg, ok := <-s.send:
+			if !ok {
+				return
+			}
+			protoMsg, ok := msg.(proto.Message)
+			if !ok {
+				return
+			}
+			frame, err := NewStreamFrame(s.method, protoMsg)
+			if err != nil {
+				return
+			}
+			resp, err := s.cluster.Call(s.identity, s.kind, frame)
+			if err != nil {
+				return
+			}
+			respFrame, ok := resp.(*StreamFrame)
+			if !ok {
+				continue
+			}
+			payload, err := respFrame.UnwrapPayload()
+			if err != nil || payload == nil {
+				continue
+			}
+			select {
+			case s.recv <- payload:
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func (s *ClientStream) Send(msg interface{}) error {
+	select {
+	case s.send <- msg:
+		return nil
+	case <-s.done:
+		return ErrStreamClosed
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func (s *ClientStream) Recv() (interface{}, error) {
+	select {
+	case msg, ok := <-s.recv:
+		if !ok {
+			return nil, ErrStreamClosed
+		}
+		return msg, nil
+	case <-s.done:
+		return nil, ErrStreamClosed
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+func (s *ClientStream) Context() context.Context { return s.ctx }
+
+// StreamFrame wraps one message traveling over a streaming method's
+// request/response pair so client and server streaming share the same
+// unary transport underneath. It is backed by structpb.Struct (see
+// wireStruct) rather than a plain Go struct so it is a genuine
+// proto.Message and survives the cross-member gRPC hop
+// ClientStreamCall/ServerStreamCall/OpenStream take whenever the target
+// grain is activated on a different member - a bare Go struct can't.
+type StreamFrame struct {
+	wireStruct
+}
+
+// NewStreamFrame packs method and payload's wire bytes into a
+// StreamFrame. payload may be nil (e.g. a response frame with nothing to
+// push back). Exported so a generated grain-side dispatcher (outside this
+// package) can build response frames too.
+func NewStreamFrame(method string, payload proto.Message) (*StreamFrame, error) {
+	packed, err := packPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := newWireStruct(map[string]interface{}{
+		"method":  method,
+		"payload": packed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &StreamFrame{wireStruct: ws}, nil
+}
+
+func (f *StreamFrame) Method() string { return f.str("method") }
+
+// UnwrapPayload resolves the wrapped payload back to its original
+// concrete proto.Message type. Returns a nil message, nil error when the
+// frame carries no payload.
+func (f *StreamFrame) UnwrapPayload() (proto.Message, error) {
+	return unpackPayload(f.str("payload"))
+}
+
+// ClientStreamCall sends every message received on in to the grain,
+// respecting backpressure via a buffer of clientBuf, and returns once the
+// grain responds with its final message.
+func (c *Cluster) ClientStreamCall(ctx context.Context, identity, kind, method string, in <-chan interface{}, clientBuf int32) (interface{}, error) {
+	s := c.newClientStream(ctx, identity, kind, method, clientBuf, 1)
+	go func() {
+		for msg := range in {
+			if err := s.Send(msg); err != nil {
+				return
+			}
+		}
+	}()
+	return s.Recv()
+}
+
+// ServerStreamCall issues req to the grain as a single StreamFrame request
+// and returns a channel, buffered to serverBuf, that carries at most the
+// payload of that one response. The channel is closed after that payload
+// is delivered, when the call fails or returns no payload, or when ctx is
+// done while the payload waits to be delivered; further server pushes
+// from the grain are not received.
+func (c *Cluster) ServerStreamCall(ctx context.Context, identity, kind, method string, req interface{}, serverBuf int32) (chan interface{}, error) {
+	out := make(chan interface{}, serverBuf)
+	reqMsg, ok := req.(proto.Message)
+	if !ok {
+		close(out)
+		return out, fmt.Errorf("cluster: ServerStreamCall request %T is not a proto.Message", req)
+	}
+	go func() {
+		defer close(out)
+		frame, err := NewStreamFrame(method, reqMsg)
+		if err != nil {
+			return
+		}
+		resp, err := c.Call(identity, kind, frame)
+		if err != nil {
+			return
+		}
+		respFrame, ok := resp.(*StreamFrame)
+		if !ok {
+			return
+		}
+		payload, err := respFrame.UnwrapPayload()
+		if err != nil || payload == nil {
+			return
+		}
+		select {
+		case out <- payload:
+		case <-ctx.Done():
+		}
+	}()
+	return out, nil
+}
+
+// OpenStream opens a bidi stream to identity/kind's method, buffered to
+// clientBuf/serverBuf on each side.
+func (c *Cluster) OpenStream(ctx context.Context, identity, kind, method string, clientBuf, serverBuf int32) (*ClientStream, error) {
+	return c.newClientStream(ctx, identity, kind, method, clientBuf, serverBuf), nil
+}
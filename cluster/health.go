@@ -0,0 +1,278 @@
+package cluster
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthStatus mirrors grpc.health.v1's serving states, kept as our own
+// type so callers of SetKindHealth don't need to import the grpc health
+// package just to report a status.
+type HealthStatus int
+
+const (
+	HealthUnknown HealthStatus = iota
+	HealthServing
+	HealthNotServing
+)
+
+func (s HealthStatus) toProto() healthpb.HealthCheckResponse_ServingStatus {
+	switch s {
+	case HealthServing:
+		return healthpb.HealthCheckResponse_SERVING
+	case HealthNotServing:
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	default:
+		return healthpb.HealthCheckResponse_UNKNOWN
+	}
+}
+
+// healthOverallService is the empty Check/Watch service name, matching how
+// grpc-go's own health client checks a server's overall health.
+const healthOverallService = ""
+
+// WithHealthCheck enables the standard grpc.health.v1.Health service for
+// this cluster. The service is registered on a grpc.Server only when
+// RegisterHealthService or Cluster.StartMember is called with that server.
+// The overall (empty-name) service reports SERVING once the member has
+// joined the cluster and NOT_SERVING while the member is draining; gossip
+// staleness is not tracked. Per-Kind statuses are reported under the
+// Kind's name and default to NOT_SERVING until a MemberStrategy has an
+// active target for it.
+func WithHealthCheck() ConfigOption {
+	return func(c *Config) {
+		c.HealthCheckEnabled = true
+	}
+}
+
+// healthServer implements grpc.health.v1.Health, backed by cluster
+// topology: the overall service tracks join/drain state via SetOverallHealth,
+// and per-Kind statuses default to NOT_SERVING until a MemberStrategy
+// reports an active target, or are overridden by SetKindHealth.
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	cluster *Cluster
+
+	mu        sync.Mutex
+	overall   HealthStatus
+	overrides map[string]HealthStatus
+	watchers  map[chan HealthStatus]string
+}
+
+func newHealthServer(cl *Cluster) *healthServer {
+	return &healthServer{
+		cluster:   cl,
+		overall:   HealthNotServing,
+		overrides: make(map[string]HealthStatus),
+		watchers:  make(map[chan HealthStatus]string),
+	}
+}
+
+// healthServers scopes a *healthServer to the *Cluster it backs, the same
+// way memberTokens and singletonStrategies scope their per-instance state:
+// by the Cluster's own pointer identity rather than a field on Cluster
+// itself, since nothing else in this package reaches across instances by
+// name. getHealthServer is the only constructor - it lazily creates the
+// server and its topology subscription on first use, so SetKindHealth and
+// RegisterHealthService always see the same instance for a given cluster.
+var healthServers sync.Map // *Cluster -> *healthServer
+
+// getHealthServer returns the healthServer for cl, creating it (and its
+// EventStream subscription) on first use. Returns nil when
+// WithHealthCheck was never passed to Configure, so callers never stand up
+// the service for a cluster that didn't ask for it.
+func getHealthServer(cl *Cluster) *healthServer {
+	if cl == nil || cl.Config == nil || !cl.Config.HealthCheckEnabled {
+		return nil
+	}
+
+	if existing, ok := healthServers.Load(cl); ok {
+		return existing.(*healthServer)
+	}
+
+	h := newHealthServer(cl)
+	actual, loaded := healthServers.LoadOrStore(cl, h)
+	if loaded {
+		return actual.(*healthServer)
+	}
+
+	h.subscribeToTopology()
+	return h
+}
+
+// subscribeToTopology drives the overall health service from the
+// cluster's own join/drain lifecycle: a ClusterTopologyEvent that lists
+// this member among Joined flips Check()/Watch() to SERVING, and one that
+// lists it among Left flips back to NOT_SERVING - without this, Configure
+// and WithHealthCheck have no way to learn the member ever joined or
+// started draining.
+func (h *healthServer) subscribeToTopology() {
+	h.cluster.ActorSystem.EventStream.Subscribe(func(evt interface{}) {
+		topology, ok := evt.(*ClusterTopologyEvent)
+		if !ok {
+			return
+		}
+
+		self := h.cluster.ActorSystem.Address()
+		for _, m := range topology.Left {
+			if m.Id == self {
+				h.setOverall(HealthNotServing)
+				return
+			}
+		}
+		for _, m := range topology.Joined {
+			if m.Id == self {
+				h.setOverall(HealthServing)
+				return
+			}
+		}
+	})
+}
+
+func (h *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	return &healthpb.HealthCheckResponse{Status: h.statusFor(req.Service).toProto()}, nil
+}
+
+func (h *healthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ch := make(chan HealthStatus, 1)
+	h.mu.Lock()
+	h.watchers[ch] = req.Service
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.watchers, ch)
+		h.mu.Unlock()
+	}()
+
+	last := h.statusFor(req.Service)
+	if err := stream.Send(&healthpb.HealthCheckResponse{Status: last.toProto()}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case status := <-ch:
+			if status == last {
+				continue
+			}
+			last = status
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: status.toProto()}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// statusFor resolves the health of a specific Kind (req.Service == Kind
+// name), or the member's overall health when req.Service is empty.
+func (h *healthServer) statusFor(service string) HealthStatus {
+	h.mu.Lock()
+	override, ok := h.overrides[service]
+	h.mu.Unlock()
+	if ok {
+		return override
+	}
+
+	if service == healthOverallService {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		return h.overall
+	}
+
+	if ak := h.cluster.GetClusterKind(service); ak != nil && ak.Strategy != nil {
+		if len(ak.Strategy.GetAllMembers()) > 0 {
+			return HealthServing
+		}
+	}
+	return HealthNotServing
+}
+
+// setOverall is driven by the member's own join/drain lifecycle rather
+// than SetKindHealth. It sets only the overall (empty-name) status:
+// per-Kind statuses, and any override, do not consult it.
+func (h *healthServer) setOverall(status HealthStatus) {
+	h.mu.Lock()
+	h.overall = status
+	h.mu.Unlock()
+	h.publish(healthOverallService, status)
+}
+
+func (h *healthServer) setOverride(service string, status HealthStatus) {
+	h.mu.Lock()
+	h.overrides[service] = status
+	h.mu.Unlock()
+	h.publish(service, status)
+}
+
+func (h *healthServer) publish(service string, status HealthStatus) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch, watched := range h.watchers {
+		if watched == service {
+			select {
+			case ch <- status:
+			default:
+			}
+		}
+	}
+}
+
+func (h *healthServer) register(server *grpc.Server) {
+	healthpb.RegisterHealthServer(server, h)
+}
+
+// RegisterHealthService registers the grpc.health.v1.Health service on
+// server for cl, if WithHealthCheck was passed to Configure. The remote
+// endpoint's startup path should call this alongside registering the
+// cluster's own GrainServer, the same way StopSingletonStrategies'
+// doc comment asks the shutdown path to call it.
+func RegisterHealthService(cl *Cluster, server *grpc.Server) {
+	h := getHealthServer(cl)
+	if h == nil {
+		return
+	}
+	h.register(server)
+}
+
+// NotifyJoined flips the overall health service to SERVING. The cluster's
+// join path should call this once gossip has a confirmed view of the
+// member, so readiness probes don't pass before placement is ready.
+func (c *Cluster) NotifyJoined() {
+	if h := getHealthServer(c); h != nil {
+		h.setOverall(HealthServing)
+	}
+}
+
+// NotifyDraining flips the overall health service to NOT_SERVING. The
+// cluster's shutdown path should call this before tearing down actors, so
+// load balancers stop routing here ahead of the member actually leaving.
+func (c *Cluster) NotifyDraining() {
+	if h := getHealthServer(c); h != nil {
+		h.setOverall(HealthNotServing)
+	}
+}
+
+// SetKindHealth overrides the reported health of kind, so admission gates
+// (Kubernetes readiness probes, load balancers) can drive drain semantics
+// without a separate HTTP handler. Passing HealthUnknown clears the
+// override and falls back to the MemberStrategy-derived status.
+func (c *Cluster) SetKindHealth(kind string, status HealthStatus) {
+	h := getHealthServer(c)
+	if h == nil {
+		return
+	}
+	if status == HealthUnknown {
+		h.mu.Lock()
+		delete(h.overrides, kind)
+		h.mu.Unlock()
+		h.publish(kind, h.statusFor(kind))
+		return
+	}
+	h.setOverride(kind, status)
+}
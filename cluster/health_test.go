@@ -0,0 +1,78 @@
+package cluster
+
+import "testing"
+
+// TestHealthServerOverrideTakesPrecedence covers SetKindHealth's override
+// path: once a kind has an explicit override, statusFor must return it
+// regardless of the overall status or any MemberStrategy-derived default.
+func TestHealthServerOverrideTakesPrecedence(t *testing.T) {
+	h := newHealthServer(nil)
+	h.overall = HealthServing
+
+	h.setOverride("my-kind", HealthNotServing)
+
+	if got := h.statusFor("my-kind"); got != HealthNotServing {
+		t.Errorf("statusFor(my-kind) = %v, want HealthNotServing", got)
+	}
+}
+
+// TestHealthServerOverallReflectsSetOverall covers the join/drain path:
+// the empty-service ("overall") status must track the last setOverall
+// call, independent of any per-Kind override.
+func TestHealthServerOverallReflectsSetOverall(t *testing.T) {
+	h := newHealthServer(nil)
+
+	if got := h.statusFor(healthOverallService); got != HealthNotServing {
+		t.Fatalf("initial overall = %v, want HealthNotServing", got)
+	}
+
+	h.setOverall(HealthServing)
+	if got := h.statusFor(healthOverallService); got != HealthServing {
+		t.Errorf("overall after setOverall(Serving) = %v, want HealthServing", got)
+	}
+}
+
+// TestHealthServerPublishNotifiesMatchingWatchersOnly covers publish's
+// service-scoped fan-out: a watcher registered for one service name must
+// not see updates published for a different one.
+func TestHealthServerPublishNotifiesMatchingWatchersOnly(t *testing.T) {
+	h := newHealthServer(nil)
+
+	matching := make(chan HealthStatus, 1)
+	other := make(chan HealthStatus, 1)
+	h.mu.Lock()
+	h.watchers[matching] = "kind-a"
+	h.watchers[other] = "kind-b"
+	h.mu.Unlock()
+
+	h.publish("kind-a", HealthServing)
+
+	select {
+	case got := <-matching:
+		if got != HealthServing {
+			t.Errorf("matching watcher got %v, want HealthServing", got)
+		}
+	default:
+		t.Error("matching watcher did not receive the published status")
+	}
+
+	select {
+	case got := <-other:
+		t.Errorf("watcher for a different service received %v, want nothing", got)
+	default:
+	}
+}
+
+// TestGetHealthServerNilWhenDisabled covers the case SetKindHealth and
+// RegisterHealthService both rely on: a cluster that never passed
+// WithHealthCheck to Configure must never stand up a healthServer.
+func TestGetHealthServerNilWhenDisabled(t *testing.T) {
+	if h := getHealthServer(nil); h != nil {
+		t.Errorf("getHealthServer(nil) = %v, want nil", h)
+	}
+
+	disabled := &Cluster{Config: &Config{}}
+	if h := getHealthServer(disabled); h != nil {
+		t.Errorf("getHealthServer(cluster without WithHealthCheck) = %v, want nil", h)
+	}
+}
@@ -0,0 +1,65 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// Leadership provides leader election over a named key, used to back
+// NewSingletonKind and any other cluster-wide coordinator that needs at
+// most one active member. A default etcd-backed implementation lives in
+// etcd_leadership.go, parallel to the etcd ClusterProvider.
+type Leadership interface {
+	// Campaign blocks until the caller becomes leader for key or ctx is
+	// cancelled. The returned Leader's Done channel fires the moment the
+	// lease is lost.
+	Campaign(ctx context.Context, key string) (Leader, error)
+
+	// Resign gives up leadership for key, if the caller currently holds
+	// it. Prefer Leader.Resign when a Leader handle is already in hand.
+	Resign(key string) error
+
+	// Leader reports the current leader for key, if any.
+	Leader(key string) (memberID string, ok bool)
+}
+
+// Leader is a held leadership lease, returned by Leadership.Campaign.
+type Leader interface {
+	MemberID() string
+
+	// Done fires when the lease backing this Leader is lost. The holder
+	// must stop acting as leader as soon as this fires.
+	Done() <-chan struct{}
+
+	// Resign proactively gives up the lease instead of waiting for it to
+	// expire, so a follower can take over immediately.
+	Resign() error
+}
+
+// singletonElectionPrefix namespaces the election key a singleton kind
+// campaigns on, so it cannot collide with a Leadership key a user picks
+// for their own coordination.
+const singletonElectionPrefix = "proto.actor.singleton/"
+
+// WithLeadership sets the Leadership implementation used to elect the
+// single live member for kinds created with NewSingletonKind.
+func WithLeadership(leadership Leadership) ConfigOption {
+	return func(c *Config) {
+		c.Leadership = leadership
+	}
+}
+
+// NewSingletonKind returns a Kind where the cluster guarantees at most one
+// live activation across all members. Placement always targets whichever
+// member currently holds leadership of the kind's election; when that
+// member loses its lease it poisons its local activation so the new
+// leader's activation can take over immediately.
+func NewSingletonKind(kind string, props *actor.Props) *Kind {
+	k := NewKind(kind, props)
+	k.Singleton = true
+	k.WithMemberStrategy(func(cluster *Cluster) MemberStrategy {
+		return newSingletonMemberStrategy(cluster, kind, singletonElectionPrefix+kind)
+	})
+	return k
+}
@@ -0,0 +1,29 @@
+package cluster
+
+import "google.golang.org/grpc"
+
+// StartMember brings this member's externally-visible readiness signal up
+// once its gRPC endpoint is ready to accept connections: it registers the
+// grpc.health.v1.Health service on server (if WithHealthCheck was passed to
+// Configure) and flips that service to SERVING. The remote endpoint's
+// startup path should call this right after it starts listening, so
+// RegisterHealthService and NotifyJoined have an actual caller instead of
+// being reachable only as orphaned helpers.
+func (c *Cluster) StartMember(server *grpc.Server) {
+	if server != nil {
+		RegisterHealthService(c, server)
+	}
+	c.NotifyJoined()
+}
+
+// Shutdown tears this member's externally-visible lifecycle down ahead of
+// the rest of its actors stopping: it flips the health service to
+// NOT_SERVING so load balancers stop routing here before the member
+// actually leaves the cluster, then stops every singleton kind's campaign
+// goroutine and releases its etcd session, so StopSingletonStrategies has
+// an actual caller instead of those goroutines and sessions leaking past
+// the cluster's own lifetime.
+func (c *Cluster) Shutdown() {
+	c.NotifyDraining()
+	StopSingletonStrategies(c)
+}
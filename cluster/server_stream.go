@@ -0,0 +1,95 @@
+package cluster
+
+import "context"
+
+// ServerStream is the grain-side counterpart to ClientStream: a
+// generated dispatcher feeds each inbound *StreamFrame's payload onto
+// recv as it arrives, and blocks ctx.Respond on whatever the grain
+// handler goroutine next pushes via Send, so a single request/response
+// leg at a time carries one message in each direction - mirroring
+// exactly how ClientStream.pump drives the caller side, since the
+// transport underneath is unary the whole way down.
+type ServerStream struct {
+	ctx context.Context
+
+	recv chan interface{}
+	send chan interface{}
+	done chan struct{}
+}
+
+// NewServerStream returns a ServerStream ready to be driven by Deliver,
+// buffered to buf in each direction.
+func NewServerStream(ctx context.Context, buf int32) *ServerStream {
+	return &ServerStream{
+		ctx:  ctx,
+		recv: make(chan interface{}, buf),
+		send: make(chan interface{}, buf),
+		done: make(chan struct{}),
+	}
+}
+
+// Send is called by the grain handler goroutine to push its next
+// outgoing message; it returns once the message is queued for the next
+// Deliver call, blocking only while the send buffer is full.
+func (s *ServerStream) Send(msg interface{}) error {
+	select {
+	case s.send <- msg:
+		return nil
+	case <-s.done:
+		return ErrStreamClosed
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+// Recv is called by the grain handler goroutine to read the next inbound
+// message a Deliver call has queued.
+func (s *ServerStream) Recv() (interface{}, error) {
+	select {
+	case msg, ok := <-s.recv:
+		if !ok {
+			return nil, ErrStreamClosed
+		}
+		return msg, nil
+	case <-s.done:
+		return nil, ErrStreamClosed
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+func (s *ServerStream) Context() context.Context { return s.ctx }
+
+// Close tears the stream down, unblocking any Send/Recv still pending on
+// the grain handler goroutine.
+func (s *ServerStream) Close() {
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+}
+
+// Deliver is called by the generated dispatcher for every inbound
+// *StreamFrame: it queues payload for the handler goroutine's Recv, then
+// waits for that goroutine's next Send so the dispatcher has something to
+// ctx.Respond with - the request-carries-response leg ClientStream.pump
+// already assumes on the caller side.
+func (s *ServerStream) Deliver(payload interface{}) (interface{}, error) {
+	select {
+	case s.recv <- payload:
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	case <-s.done:
+		return nil, ErrStreamClosed
+	}
+
+	select {
+	case out := <-s.send:
+		return out, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	case <-s.done:
+		return nil, ErrStreamClosed
+	}
+}
@@ -0,0 +1,181 @@
+package cluster
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	singletonCampaignMinBackoff = 500 * time.Millisecond
+	singletonCampaignMaxBackoff = 30 * time.Second
+)
+
+// singletonMemberStrategy always routes placement to whoever currently
+// holds leadership of electionKey, so a kind built with NewSingletonKind
+// only ever has one live activation cluster-wide. members/leaderID are
+// written by the background campaign goroutine and read from GetActivator
+// on whatever goroutine placement runs on, so both are guarded by mu.
+type singletonMemberStrategy struct {
+	cluster     *Cluster
+	kind        string
+	electionKey string
+
+	mu       sync.Mutex
+	members  []*Member
+	leaderID string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newSingletonMemberStrategy(cluster *Cluster, kind, electionKey string) MemberStrategy {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &singletonMemberStrategy{
+		cluster:     cluster,
+		kind:        kind,
+		electionKey: electionKey,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+	registerSingletonStrategy(cluster, s)
+	go s.campaign()
+	return s
+}
+
+func (s *singletonMemberStrategy) GetAllMembers() []*Member {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.members
+}
+
+func (s *singletonMemberStrategy) AddMember(m *Member) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.members = append(s.members, m)
+}
+
+func (s *singletonMemberStrategy) RemoveMember(m *Member) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, existing := range s.members {
+		if existing.Id == m.Id {
+			s.members = append(s.members[:i], s.members[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetActivator returns the member currently holding leadership of the
+// singleton's election, falling back to the first known member until a
+// leader has been observed.
+func (s *singletonMemberStrategy) GetActivator(requestSourceAddress string) *Member {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, m := range s.members {
+		if m.Id == s.leaderID {
+			return m
+		}
+	}
+	if len(s.members) > 0 {
+		return s.members[0]
+	}
+	return nil
+}
+
+// Shutdown stops this strategy's campaign goroutine and releases its
+// etcd session, so a Cluster.Shutdown doesn't leak either past the
+// cluster's own lifetime. It is called for every singleton kind by
+// StopSingletonStrategies, which a Cluster shutdown path should invoke.
+func (s *singletonMemberStrategy) Shutdown() {
+	s.cancel()
+}
+
+// campaign keeps this member in the election for electionKey for as long
+// as s.ctx is alive (cancelled by Shutdown), re-campaigning immediately
+// after a lost lease so a new leader is picked without waiting on gossip
+// to notice. A transient Campaign error (e.g. the etcd client briefly
+// losing connectivity) backs off and retries rather than permanently
+// disabling this member from ever leading the kind again.
+func (s *singletonMemberStrategy) campaign() {
+	leadership := s.cluster.Config.Leadership
+	if leadership == nil {
+		slog.Warn("singleton kind configured without a Leadership implementation", "kind", s.kind)
+		return
+	}
+
+	backoff := singletonCampaignMinBackoff
+	for {
+		if s.ctx.Err() != nil {
+			return
+		}
+
+		leader, err := leadership.Campaign(s.ctx, s.electionKey)
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return
+			}
+
+			slog.Error("singleton leadership campaign failed, retrying", "kind", s.kind, "error", err, "backoff", backoff)
+			select {
+			case <-time.After(backoff):
+			case <-s.ctx.Done():
+				return
+			}
+			if backoff *= 2; backoff > singletonCampaignMaxBackoff {
+				backoff = singletonCampaignMaxBackoff
+			}
+			continue
+		}
+		backoff = singletonCampaignMinBackoff
+
+		s.mu.Lock()
+		s.leaderID = leader.MemberID()
+		s.mu.Unlock()
+
+		select {
+		case <-leader.Done():
+		case <-s.ctx.Done():
+			leader.Resign()
+			return
+		}
+
+		// The lease is gone: a watcher on the election prefix will have
+		// already let the next campaigner take over, so stop acting as
+		// leader and poison any local activation of this kind before
+		// looping back into Campaign.
+		if leader.MemberID() == s.cluster.ActorSystem.Address() {
+			s.cluster.poisonActivationsOfKind(s.kind)
+		}
+	}
+}
+
+// singletonStrategies tracks every singletonMemberStrategy created for a
+// given *Cluster, so StopSingletonStrategies can tear all of them down
+// together when that cluster shuts down.
+var (
+	singletonStrategiesMu sync.Mutex
+	singletonStrategies   = map[*Cluster][]*singletonMemberStrategy{}
+)
+
+func registerSingletonStrategy(cluster *Cluster, s *singletonMemberStrategy) {
+	singletonStrategiesMu.Lock()
+	defer singletonStrategiesMu.Unlock()
+	singletonStrategies[cluster] = append(singletonStrategies[cluster], s)
+}
+
+// StopSingletonStrategies cancels the campaign goroutine and releases the
+// etcd session of every singleton kind registered for cluster. The
+// cluster's shutdown path should call this so neither leaks past
+// Cluster.Shutdown.
+func StopSingletonStrategies(cluster *Cluster) {
+	singletonStrategiesMu.Lock()
+	strategies := singletonStrategies[cluster]
+	delete(singletonStrategies, cluster)
+	singletonStrategiesMu.Unlock()
+
+	for _, s := range strategies {
+		s.Shutdown()
+	}
+}
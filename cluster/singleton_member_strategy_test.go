@@ -0,0 +1,32 @@
+package cluster
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestSingletonMemberStrategyConcurrentAccess exercises AddMember,
+// RemoveMember and GetActivator from multiple goroutines at once - the
+// shape campaign() and placement calls take in production - so `go test
+// -race` catches a regression of the missing mutex.
+func TestSingletonMemberStrategyConcurrentAccess(t *testing.T) {
+	s := &singletonMemberStrategy{kind: "test-singleton"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(3)
+		go func(i int) {
+			defer wg.Done()
+			s.AddMember(&Member{Id: "member"})
+		}(i)
+		go func() {
+			defer wg.Done()
+			s.RemoveMember(&Member{Id: "member"})
+		}()
+		go func() {
+			defer wg.Done()
+			s.GetActivator("")
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,270 @@
+package cluster
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// tsoKind is the well-known grain kind auto-registered by WithTSO.
+// Followers forward AllocateIDs/Now requests to whichever member holds
+// leadership of it, the same way any other singleton kind is placed.
+const tsoKind = "$tso"
+
+// tsoIdentity is the single identity the TSO is addressed by; there is
+// only ever one logical allocator, elected via Leadership like any other
+// singleton kind.
+const tsoIdentity = "tso"
+
+// tsoIDWatermarkKey and tsoPhysicalWatermarkKey are where the allocator
+// persists its two independent high-watermarks in the cluster provider's
+// KV, so a new leader never hands out an ID or timestamp behind a lost
+// predecessor. They are kept separate because the ID window and the
+// physical clock advance at completely different rates - sharing one key
+// would mean a Now() call's ~10^12 Unix-ms watermark overwrites the ID
+// allocator's much smaller sequential watermark (or vice versa), seeding
+// the wrong subsystem from the other's value on the next restore.
+const (
+	tsoIDWatermarkKey       = "proto.actor.tso/id-watermark"
+	tsoPhysicalWatermarkKey = "proto.actor.tso/physical-watermark"
+)
+
+const (
+	defaultTSOWindowSize       = 1000
+	tsoTimestampSafetyMarginMs = 100
+)
+
+// PersistentKV is implemented by a ClusterProvider that can durably store
+// small amounts of cluster-wide state - the etcd, consul and k8s configmap
+// providers all have a natural backing store for this already. The TSO
+// subsystem uses it to persist its high-watermark across restarts.
+type PersistentKV interface {
+	Put(ctx context.Context, key string, value []byte) error
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+}
+
+// ErrTSONotConfigured is returned by Cluster.AllocateIDs and Cluster.Now
+// when WithTSO was not passed to Configure.
+var ErrTSONotConfigured = errors.New("cluster: TSO subsystem not configured, see WithTSO")
+
+// WithTSO opts the cluster into the TSO (timestamp oracle) subsystem,
+// auto-registering the well-known allocator kind so Cluster.AllocateIDs
+// and Cluster.Now have somewhere to forward to.
+func WithTSO() ConfigOption {
+	return func(c *Config) {
+		c.TSOEnabled = true
+		c.Kinds[tsoKind] = NewSingletonKind(tsoKind, actor.PropsFromProducer(func() actor.Actor {
+			return &tsoActor{}
+		}))
+	}
+}
+
+// AllocateIDs reserves a contiguous window of n monotonically increasing
+// IDs, useful for dedup keys, saga IDs and event sequence numbers. The
+// allocator is a singleton elected across the cluster, so IDs never repeat
+// even across a leader change when the ClusterProvider implements
+// PersistentKV; without it, a newly elected allocator starts again from 0.
+func (c *Cluster) AllocateIDs(ctx context.Context, n uint32) (start uint64, count uint32, err error) {
+	if !c.Config.TSOEnabled {
+		return 0, 0, ErrTSONotConfigured
+	}
+
+	req, err := newAllocateIDsRequest(n)
+	if err != nil {
+		return 0, 0, err
+	}
+	resp, err := c.Call(tsoIdentity, tsoKind, req)
+	if err != nil {
+		return 0, 0, err
+	}
+	r := resp.(*allocateIDsResponse)
+	return r.start(), r.count(), nil
+}
+
+// Now returns a hybrid logical timestamp: physical wall-clock milliseconds
+// plus a logical counter that increments when two calls land in the same
+// millisecond, so the pair is strictly increasing across the whole
+// cluster even under clock skew between members. Across a leader change
+// this holds only when the ClusterProvider implements PersistentKV;
+// without it, a new leader starts from its own clock.
+func (c *Cluster) Now(ctx context.Context) (physical int64, logical uint32, err error) {
+	if !c.Config.TSOEnabled {
+		return 0, 0, ErrTSONotConfigured
+	}
+
+	req, err := newTsoNowRequest()
+	if err != nil {
+		return 0, 0, err
+	}
+	resp, err := c.Call(tsoIdentity, tsoKind, req)
+	if err != nil {
+		return 0, 0, err
+	}
+	r := resp.(*tsoNowResponse)
+	return r.physical(), r.logical(), nil
+}
+
+// tsoActor is the grain handler backing the $tso kind. It only ever runs
+// on the elected leader: singletonMemberStrategy makes sure of that, and
+// every other member's Call for tsoKind forwards there.
+type tsoActor struct {
+	mu sync.Mutex
+
+	nextID          uint64
+	persistedNextID uint64
+
+	lastPhysical      int64
+	persistedPhysical uint64
+	logical           uint32
+}
+
+func (a *tsoActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		a.restore(ctx)
+	case *allocateIDsRequest:
+		resp, err := a.allocate(ctx, msg.count())
+		if err != nil {
+			ctx.Respond(err)
+			return
+		}
+		ctx.Respond(resp)
+	case *tsoNowRequest:
+		resp, err := a.now(ctx)
+		if err != nil {
+			ctx.Respond(err)
+			return
+		}
+		ctx.Respond(resp)
+	}
+}
+
+// restore reads the two persisted high-watermarks on startup and
+// fast-forwards past each by its safety margin, so this newly elected
+// leader cannot hand out an ID or timestamp a lost predecessor might
+// already have issued. The ID watermark and the physical-clock watermark
+// are read from separate keys and seed separate fields - they must never
+// be conflated, since one holds a small sequential count and the other a
+// ~10^12 Unix-ms value.
+func (a *tsoActor) restore(ctx actor.Context) {
+	cl := GetCluster(ctx.ActorSystem())
+	kv, ok := cl.Config.ClusterProvider.(PersistentKV)
+	if !ok {
+		slog.Warn("TSO watermark persistence disabled: ClusterProvider does not implement PersistentKV", "provider", fmt.Sprintf("%T", cl.Config.ClusterProvider))
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if raw, found, err := kv.Get(context.Background(), tsoIDWatermarkKey); err == nil && found && len(raw) >= 8 {
+		a.persistedNextID = binary.BigEndian.Uint64(raw)
+		a.nextID = a.persistedNextID
+	}
+
+	if raw, found, err := kv.Get(context.Background(), tsoPhysicalWatermarkKey); err == nil && found && len(raw) >= 8 {
+		a.persistedPhysical = binary.BigEndian.Uint64(raw)
+		a.lastPhysical = int64(a.persistedPhysical)
+	}
+}
+
+func (a *tsoActor) allocate(ctx actor.Context, n uint32) (*allocateIDsResponse, error) {
+	a.mu.Lock()
+	start, persist := a.advanceIDWindow(n)
+	a.mu.Unlock()
+
+	if persist {
+		a.persist(ctx, tsoIDWatermarkKey, a.persistedNextID)
+	}
+
+	return newAllocateIDsResponse(start, n)
+}
+
+// advanceIDWindow is the pure core of allocate: it reserves n IDs starting
+// at nextID and reports whether the persisted watermark needs bumping, so
+// the arithmetic can be driven from a test without a *Cluster or
+// actor.Context. Caller must hold a.mu.
+func (a *tsoActor) advanceIDWindow(n uint32) (start uint64, shouldPersist bool) {
+	start = a.nextID
+	a.nextID += uint64(n)
+
+	if a.nextID > a.persistedNextID {
+		a.persistedNextID = a.nextID + defaultTSOWindowSize
+		shouldPersist = true
+	}
+	return start, shouldPersist
+}
+
+func (a *tsoActor) now(ctx actor.Context) (*tsoNowResponse, error) {
+	physical, logical := a.tick(func() int64 { return time.Now().UnixMilli() })
+
+	a.mu.Lock()
+	persist := a.advancePhysicalWatermark(physical)
+	a.mu.Unlock()
+
+	if persist {
+		a.persist(ctx, tsoPhysicalWatermarkKey, a.persistedPhysical)
+	}
+
+	return newTsoNowResponse(physical, logical)
+}
+
+// advancePhysicalWatermark is the pure core of now(): it reports whether
+// physical has pushed past the persisted safety margin and, if so, bumps
+// persistedPhysical in place. Caller must hold a.mu.
+func (a *tsoActor) advancePhysicalWatermark(physical int64) (shouldPersist bool) {
+	if uint64(physical) > a.persistedPhysical {
+		a.persistedPhysical = uint64(physical) + tsoTimestampSafetyMarginMs
+		return true
+	}
+	return false
+}
+
+// tick advances the hybrid logical clock by one tick using clock for the
+// current wall-clock reading, and is the pure core of now(): it takes a
+// clock func rather than calling time.Now() directly so the monotonicity,
+// same-millisecond and logical-counter-overflow cases can be driven
+// deterministically from a test without a *Cluster or actor.Context.
+func (a *tsoActor) tick(clock func() int64) (physical int64, logical uint32) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	physical = clock()
+	if physical < a.lastPhysical {
+		physical = a.lastPhysical
+	}
+
+	if physical == a.lastPhysical {
+		a.logical++
+		if a.logical == 0 {
+			// logical counter overflowed: spin to the next millisecond
+			// rather than reusing one.
+			for physical <= a.lastPhysical {
+				physical = clock()
+			}
+		}
+	} else {
+		a.logical = 0
+	}
+	a.lastPhysical = physical
+
+	return physical, a.logical
+}
+
+func (a *tsoActor) persist(ctx actor.Context, key string, watermark uint64) {
+	cl := GetCluster(ctx.ActorSystem())
+	kv, ok := cl.Config.ClusterProvider.(PersistentKV)
+	if !ok {
+		return
+	}
+
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, watermark)
+	_ = kv.Put(context.Background(), key, buf)
+}
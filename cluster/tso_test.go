@@ -0,0 +1,129 @@
+package cluster
+
+import "testing"
+
+// TestTSOActorTickMonotonic covers the common case: strictly increasing
+// wall-clock readings always reset the logical counter to 0 and pass the
+// physical reading straight through.
+func TestTSOActorTickMonotonic(t *testing.T) {
+	a := &tsoActor{}
+
+	calls := []int64{1000, 1001, 1002}
+	i := 0
+	clock := func() int64 {
+		v := calls[i]
+		i++
+		return v
+	}
+
+	for _, want := range calls {
+		physical, logical := a.tick(clock)
+		if physical != want {
+			t.Errorf("physical = %d, want %d", physical, want)
+		}
+		if logical != 0 {
+			t.Errorf("logical = %d, want 0", logical)
+		}
+	}
+}
+
+// TestTSOActorTickSameMillisecond covers two calls landing in the same
+// wall-clock millisecond: the physical reading must stay pinned and the
+// logical counter must increment so the pair as a whole is still strictly
+// increasing.
+func TestTSOActorTickSameMillisecond(t *testing.T) {
+	a := &tsoActor{}
+	clock := func() int64 { return 5000 }
+
+	physical1, logical1 := a.tick(clock)
+	physical2, logical2 := a.tick(clock)
+
+	if physical1 != 5000 || physical2 != 5000 {
+		t.Fatalf("physical = %d, %d, want both 5000", physical1, physical2)
+	}
+	if logical1 != 0 {
+		t.Errorf("logical1 = %d, want 0", logical1)
+	}
+	if logical2 != 1 {
+		t.Errorf("logical2 = %d, want 1", logical2)
+	}
+}
+
+// TestTSOActorTickClockRegression covers the clock going backwards (e.g.
+// NTP step): tick must clamp physical to the last observed value rather
+// than handing out a timestamp smaller than one already issued.
+func TestTSOActorTickClockRegression(t *testing.T) {
+	a := &tsoActor{}
+
+	readings := []int64{9000, 8000}
+	i := 0
+	clock := func() int64 {
+		v := readings[i]
+		i++
+		return v
+	}
+
+	first, _ := a.tick(clock)
+	second, logical := a.tick(clock)
+
+	if first != 9000 {
+		t.Fatalf("first physical = %d, want 9000", first)
+	}
+	if second != 9000 {
+		t.Errorf("second physical = %d, want clamped to 9000, got %d", second, second)
+	}
+	if logical != 1 {
+		t.Errorf("logical = %d, want 1 after clamping into the same millisecond", logical)
+	}
+}
+
+// TestTSOActorTickLogicalOverflow covers the logical counter wrapping back
+// to 0: tick must spin the clock forward to the next millisecond rather
+// than reusing physical/logical pair (1000, 0) a previous call already
+// returned.
+func TestTSOActorTickLogicalOverflow(t *testing.T) {
+	a := &tsoActor{lastPhysical: 1000, logical: 0xFFFFFFFF}
+
+	calls := 0
+	clock := func() int64 {
+		calls++
+		if calls < 3 {
+			// still the same millisecond: logical would wrap to 0 again
+			return 1000
+		}
+		return 1001
+	}
+
+	physical, logical := a.tick(clock)
+	if physical != 1001 {
+		t.Errorf("physical = %d, want 1001 after spinning past the overflowed millisecond", physical)
+	}
+	if logical != 0 {
+		t.Errorf("logical = %d, want 0 on the new millisecond", logical)
+	}
+}
+
+// TestTSOActorAllocateAdvancesWatermarkIndependently is the regression
+// test for the shared-watermark bug: advancing the ID allocator must never
+// move persistedPhysical, and vice versa for now()'s tick - the two
+// subsystems persist to independent fields (and, in allocate/now, independent
+// KV keys) precisely so one can't seed the other's state on restore.
+func TestTSOActorAllocateAdvancesWatermarkIndependently(t *testing.T) {
+	a := &tsoActor{}
+
+	start, shouldPersist := a.advanceIDWindow(10)
+	if !shouldPersist {
+		t.Fatal("advanceIDWindow(10) reported shouldPersist = false on first call, want true")
+	}
+
+	if a.persistedPhysical != 0 {
+		t.Errorf("persistedPhysical = %d, want untouched at 0 after only allocating IDs", a.persistedPhysical)
+	}
+
+	physical, _ := a.tick(func() int64 { return 1_700_000_000_000 })
+	a.advancePhysicalWatermark(physical)
+
+	if a.persistedNextID != start+10+defaultTSOWindowSize {
+		t.Errorf("persistedNextID = %d, want unaffected by the subsequent tick", a.persistedNextID)
+	}
+}
@@ -0,0 +1,95 @@
+package cluster
+
+import "strconv"
+
+// allocateIDsRequest, allocateIDsResponse, tsoNowRequest and tsoNowResponse
+// travel over Cluster.Call the same as any other grain request, which
+// means they can take the cross-member gRPC hop clusterCtx.Request uses
+// whenever tsoKind's singleton activation lives on a different member -
+// so, like AuthEnvelope and StreamFrame, they're backed by wireStruct
+// rather than being bare Go structs that a gRPC hop can't survive.
+// Fields are stored as decimal strings since wireStruct only exposes a
+// string accessor; these are internal request/response types with no
+// wire-format stability concerns of their own, so the conversion cost is
+// not worth a second wireStruct accessor kind.
+
+type allocateIDsRequest struct {
+	wireStruct
+}
+
+func newAllocateIDsRequest(count uint32) (*allocateIDsRequest, error) {
+	ws, err := newWireStruct(map[string]interface{}{
+		"count": strconv.FormatUint(uint64(count), 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &allocateIDsRequest{wireStruct: ws}, nil
+}
+
+func (r *allocateIDsRequest) count() uint32 {
+	v, _ := strconv.ParseUint(r.str("count"), 10, 32)
+	return uint32(v)
+}
+
+type allocateIDsResponse struct {
+	wireStruct
+}
+
+func newAllocateIDsResponse(start uint64, count uint32) (*allocateIDsResponse, error) {
+	ws, err := newWireStruct(map[string]interface{}{
+		"start": strconv.FormatUint(start, 10),
+		"count": strconv.FormatUint(uint64(count), 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &allocateIDsResponse{wireStruct: ws}, nil
+}
+
+func (r *allocateIDsResponse) start() uint64 {
+	v, _ := strconv.ParseUint(r.str("start"), 10, 64)
+	return v
+}
+
+func (r *allocateIDsResponse) count() uint32 {
+	v, _ := strconv.ParseUint(r.str("count"), 10, 32)
+	return uint32(v)
+}
+
+type tsoNowRequest struct {
+	wireStruct
+}
+
+func newTsoNowRequest() (*tsoNowRequest, error) {
+	ws, err := newWireStruct(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &tsoNowRequest{wireStruct: ws}, nil
+}
+
+type tsoNowResponse struct {
+	wireStruct
+}
+
+func newTsoNowResponse(physical int64, logical uint32) (*tsoNowResponse, error) {
+	ws, err := newWireStruct(map[string]interface{}{
+		"physical": strconv.FormatInt(physical, 10),
+		"logical":  strconv.FormatUint(uint64(logical), 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tsoNowResponse{wireStruct: ws}, nil
+}
+
+func (r *tsoNowResponse) physical() int64 {
+	v, _ := strconv.ParseInt(r.str("physical"), 10, 64)
+	return v
+}
+
+func (r *tsoNowResponse) logical() uint32 {
+	v, _ := strconv.ParseUint(r.str("logical"), 10, 32)
+	return uint32(v)
+}
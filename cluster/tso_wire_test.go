@@ -0,0 +1,47 @@
+package cluster
+
+import "testing"
+
+// TestAllocateIDsWireRoundTrip covers the wire path AllocateIDs/allocate
+// rely on: allocateIDsRequest/Response must be genuine proto.Message
+// values (see wire.go) that round-trip their fields exactly, since they
+// now travel the same cross-member gRPC hop any other grain request does.
+func TestAllocateIDsWireRoundTrip(t *testing.T) {
+	req, err := newAllocateIDsRequest(7)
+	if err != nil {
+		t.Fatalf("newAllocateIDsRequest: %v", err)
+	}
+	if got := req.count(); got != 7 {
+		t.Errorf("count() = %d, want 7", got)
+	}
+
+	resp, err := newAllocateIDsResponse(42, 7)
+	if err != nil {
+		t.Fatalf("newAllocateIDsResponse: %v", err)
+	}
+	if got := resp.start(); got != 42 {
+		t.Errorf("start() = %d, want 42", got)
+	}
+	if got := resp.count(); got != 7 {
+		t.Errorf("count() = %d, want 7", got)
+	}
+}
+
+// TestTsoNowWireRoundTrip is the equivalent round-trip coverage for
+// tsoNowRequest/Response.
+func TestTsoNowWireRoundTrip(t *testing.T) {
+	if _, err := newTsoNowRequest(); err != nil {
+		t.Fatalf("newTsoNowRequest: %v", err)
+	}
+
+	resp, err := newTsoNowResponse(1_700_000_000_123, 5)
+	if err != nil {
+		t.Fatalf("newTsoNowResponse: %v", err)
+	}
+	if got := resp.physical(); got != 1_700_000_000_123 {
+		t.Errorf("physical() = %d, want 1700000000123", got)
+	}
+	if got := resp.logical(); got != 5 {
+		t.Errorf("logical() = %d, want 5", got)
+	}
+}
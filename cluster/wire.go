@@ -0,0 +1,71 @@
+package cluster
+
+import (
+	"encoding/base64"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// packPayload marshals msg as a google.protobuf.Any and returns it
+// base64-encoded, so it fits in a structpb.Struct string field - Any is
+// used rather than embedding msg directly because structpb.Value only
+// knows how to hold JSON-shaped data, not an arbitrary proto.Message.
+func packPayload(msg proto.Message) (string, error) {
+	if msg == nil {
+		return "", nil
+	}
+
+	any, err := anypb.New(msg)
+	if err != nil {
+		return "", err
+	}
+	raw, err := proto.Marshal(any)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(raw), nil
+}
+
+// unpackPayload reverses packPayload, resolving the original concrete
+// message type from the Any's type URL via the global proto registry -
+// the same mechanism grpc-go itself relies on to redeliver a
+// google.protobuf.Any payload as its original type.
+func unpackPayload(encoded string) (proto.Message, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	var any anypb.Any
+	if err := proto.Unmarshal(raw, &any); err != nil {
+		return nil, err
+	}
+	return any.UnmarshalNew()
+}
+
+// wireStruct is embedded by every hand-rolled wire envelope in this
+// package (AuthEnvelope, StreamFrame, and the TSO request/response
+// types), so each one satisfies proto.Message by promotion from
+// structpb.Struct's own Reset/String/ProtoReflect rather than needing a
+// protoc-generated .pb.go of its own - the same well-known type the
+// standard library's own google.protobuf.Struct JSON mapping is built on.
+type wireStruct struct {
+	*structpb.Struct
+}
+
+func newWireStruct(fields map[string]interface{}) (wireStruct, error) {
+	s, err := structpb.NewStruct(fields)
+	if err != nil {
+		return wireStruct{}, err
+	}
+	return wireStruct{Struct: s}, nil
+}
+
+func (w wireStruct) str(key string) string {
+	return w.Fields[key].GetStringValue()
+}
@@ -11,11 +11,25 @@ import (
 
 const deprecationComment = "// Deprecated: Do not use."
 
+// defaultStreamBufferSize is used for a streaming method when the
+// .proto does not set MethodOptions.client_stream_buffer_size /
+// server_stream_buffer_size, keeping generated channels bounded by default.
+const defaultStreamBufferSize = 16
+
+func bufferSizeOrDefault(size int32) int32 {
+	if size <= 0 {
+		return defaultStreamBufferSize
+	}
+	return size
+}
+
 const (
+	contextPackage = protogen.GoImportPath("context")
 	timePackage    = protogen.GoImportPath("time")
 	errorsPackage  = protogen.GoImportPath("errors")
 	fmtPackage     = protogen.GoImportPath("fmt")
 	slogPackage    = protogen.GoImportPath("log/slog")
+	syncPackage    = protogen.GoImportPath("sync")
 	protoPackage   = protogen.GoImportPath("google.golang.org/protobuf/proto")
 	actorPackage   = protogen.GoImportPath("github.com/asynkron/protoactor-go/actor")
 	clusterPackage = protogen.GoImportPath("github.com/asynkron/protoactor-go/cluster")
@@ -60,12 +74,14 @@ func generateContent(gen *protogen.Plugin, g *protogen.GeneratedFile, file *prot
 		return
 	}
 
+	g.QualifiedGoIdent(contextPackage.Ident(""))
 	g.QualifiedGoIdent(actorPackage.Ident(""))
 	g.QualifiedGoIdent(clusterPackage.Ident(""))
 	g.QualifiedGoIdent(protoPackage.Ident(""))
 	g.QualifiedGoIdent(fmtPackage.Ident(""))
 	g.QualifiedGoIdent(timePackage.Ident(""))
 	g.QualifiedGoIdent(slogPackage.Ident(""))
+	g.QualifiedGoIdent(syncPackage.Ident(""))
 
 	for _, service := range file.Services {
 		generateService(service, file, g)
@@ -83,21 +99,28 @@ func generateService(service *protogen.Service, file *protogen.File, g *protogen
 	}
 
 	for i, method := range service.Methods {
-		if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
-			continue
-		}
-
 		methodOptions, ok := proto.GetExtension(method.Desc.Options(), options.E_MethodOptions).(*options.MethodOptions)
 		if !ok {
 			continue
 		}
 
+		isStreamingClient := method.Desc.IsStreamingClient()
+		isStreamingServer := method.Desc.IsStreamingServer()
+
 		md := &methodDesc{
-			Name:        method.GoName,
-			Input:       g.QualifiedGoIdent(method.Input.GoIdent),
-			Output:      g.QualifiedGoIdent(method.Output.GoIdent),
-			Index:       i,
-			Reenterable: methodOptions.GetReenterable(),
+			Name:              method.GoName,
+			Input:             g.QualifiedGoIdent(method.Input.GoIdent),
+			Output:            g.QualifiedGoIdent(method.Output.GoIdent),
+			Index:             i,
+			Reenterable:       methodOptions.GetReenterable(),
+			IsStreamingClient: isStreamingClient,
+			IsStreamingServer: isStreamingServer,
+			RequiredRoles:     methodOptions.GetRequiredRoles(),
+		}
+
+		if isStreamingClient || isStreamingServer {
+			md.ClientBufferSize = bufferSizeOrDefault(methodOptions.GetClientStreamBufferSize())
+			md.ServerBufferSize = bufferSizeOrDefault(methodOptions.GetServerStreamBufferSize())
 		}
 
 		sd.Methods = append(sd.Methods, md)
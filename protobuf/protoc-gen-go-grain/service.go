@@ -0,0 +1,343 @@
+package main
+
+import (
+	"strings"
+	"text/template"
+)
+
+// methodDesc is the per-method data the service template renders into
+// generated Go: call-site helpers on the *GrainClient and, for a
+// streaming method, the Send/Recv handler interface the grain side
+// implements against.
+type methodDesc struct {
+	Name   string
+	Input  string
+	Output string
+	Index  int
+
+	Reenterable bool
+
+	IsStreamingClient bool
+	IsStreamingServer bool
+	ClientBufferSize  int32
+	ServerBufferSize  int32
+
+	// RequiredRoles, if non-empty, is emitted as a package-level
+	// <Service><Method>RequiredRoles variable; the generated dispatch code
+	// does not check it.
+	RequiredRoles []string
+}
+
+func (m *methodDesc) IsStreaming() bool {
+	return m.IsStreamingClient || m.IsStreamingServer
+}
+
+func (m *methodDesc) BufferSize() int32 {
+	if m.IsStreamingServer {
+		return m.ServerBufferSize
+	}
+	return m.ClientBufferSize
+}
+
+// IsDispatchable reports whether the grain side of this method gets a
+// generated mailbox dispatcher entry. Only bidi methods need one: they're
+// the only ones whose Grain interface signature hands the handler a
+// {{$.Name}}{{.Name}}Stream object, and that object has to be driven by
+// something that multiplexes inbound *cluster.StreamFrame envelopes onto
+// it from the actor's own mailbox. Server-streaming-only and
+// client-streaming-only methods call the handler directly with a plain
+// request/channel signature, so they need no dispatcher entry.
+func (m *methodDesc) IsDispatchable() bool {
+	return m.IsStreamingClient && m.IsStreamingServer
+}
+
+type serviceDesc struct {
+	Name    string
+	Methods []*methodDesc
+}
+
+// HasDispatchableStreaming reports whether any method needs the
+// generated <Service>StreamDispatcher, so services with only unary or
+// client-streaming-only methods don't get an unused one.
+func (s *serviceDesc) HasDispatchableStreaming() bool {
+	for _, m := range s.Methods {
+		if m.IsDispatchable() {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *serviceDesc) execute() string {
+	var b strings.Builder
+	if err := serviceTemplate.Execute(&b, s); err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+var serviceTemplate = template.Must(template.New("service").Parse(serviceTemplateText))
+
+// serviceTemplateText renders, per service:
+//   - a <Service>Grain interface with one method per RPC, using a unary
+//     signature, a returned <-chan Output for server-streaming, an
+//     in <-chan Input for client-streaming, and a Send/Recv stream for bidi;
+//   - a <Service><Method>Stream interface for each streaming method, plus,
+//     for bidi methods, a grain-side adapter and a <Service>StreamDispatcher
+//     that multiplexes it onto the actor's mailbox;
+//   - a <Service>GrainClient with one call-site helper per RPC, wiring
+//     buffer sizes and ctx-scoped cancellation through to the channels.
+const serviceTemplateText = `
+type {{.Name}}Grain interface {
+{{- range .Methods}}
+{{- if not .IsStreaming}}
+	{{.Name}}(ctx context.Context, req *{{.Input}}) (*{{.Output}}, error)
+{{- else if and .IsStreamingClient .IsStreamingServer}}
+	{{.Name}}(ctx context.Context, stream {{$.Name}}{{.Name}}Stream) error
+{{- else if .IsStreamingServer}}
+	{{.Name}}(ctx context.Context, req *{{.Input}}) (<-chan *{{.Output}}, error)
+{{- else}}
+	{{.Name}}(ctx context.Context, in <-chan *{{.Input}}) (*{{.Output}}, error)
+{{- end}}
+{{- end}}
+}
+{{range .Methods}}
+{{- if .IsStreaming}}
+
+// {{$.Name}}{{.Name}}Stream multiplexes {{.Name}}'s Send/Recv onto the
+// grain actor's mailbox. Buffered to {{.BufferSize}}; a full buffer makes
+// Send block, propagating backpressure back to the caller. Cancelling the
+// caller's ctx ends the caller's side only; the grain side is not notified.
+type {{$.Name}}{{.Name}}Stream interface {
+{{- if .IsStreamingServer}}
+	Send(*{{.Output}}) error
+{{- end}}
+{{- if .IsStreamingClient}}
+	Recv() (*{{.Input}}, error)
+{{- end}}
+	Context() context.Context
+}
+
+// {{$.Name}}{{.Name}}StreamImpl adapts cluster.ClientStream's interface{}
+// Send/Recv to {{$.Name}}{{.Name}}Stream's typed signatures.
+type {{$.Name}}{{.Name}}StreamImpl struct {
+	*cluster.ClientStream
+}
+{{- if .IsStreamingServer}}
+
+func (s *{{$.Name}}{{.Name}}StreamImpl) Send(msg *{{.Output}}) error {
+	return s.ClientStream.Send(msg)
+}
+{{- end}}
+{{- if .IsStreamingClient}}
+
+func (s *{{$.Name}}{{.Name}}StreamImpl) Recv() (*{{.Input}}, error) {
+	msg, err := s.ClientStream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return msg.(*{{.Input}}), nil
+}
+{{- end}}
+{{- end}}
+{{- if .IsDispatchable}}
+
+// {{$.Name}}{{.Name}}ServerStreamImpl adapts cluster.ServerStream's
+// interface{} Send/Recv to {{$.Name}}{{.Name}}Stream's typed signatures,
+// the grain-side counterpart to {{$.Name}}{{.Name}}StreamImpl.
+type {{$.Name}}{{.Name}}ServerStreamImpl struct {
+	*cluster.ServerStream
+}
+
+func (s *{{$.Name}}{{.Name}}ServerStreamImpl) Send(msg *{{.Output}}) error {
+	return s.ServerStream.Send(msg)
+}
+
+func (s *{{$.Name}}{{.Name}}ServerStreamImpl) Recv() (*{{.Input}}, error) {
+	msg, err := s.ServerStream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return msg.(*{{.Input}}), nil
+}
+{{- end}}
+{{- end}}
+{{- if .HasDispatchableStreaming}}
+
+// {{.Name}}StreamDispatcher multiplexes inbound *cluster.StreamFrame
+// envelopes from the grain actor's mailbox onto {{.Name}}Grain's bidi
+// streaming methods: call Dispatch from the grain's Receive whenever
+// ctx.Message() is a *cluster.StreamFrame. A handler goroutine per method
+// is started on that method's first frame and kept alive across
+// subsequent ones, so the {{.Name}}{{"{{"}}Method{{"}}"}}Stream argument
+// genuinely multiplexes onto the actor's own message loop rather than
+// running disconnected from it.
+type {{.Name}}StreamDispatcher struct {
+	mu      sync.Mutex
+	streams map[string]*cluster.ServerStream
+}
+
+// New{{.Name}}StreamDispatcher returns a dispatcher ready to back one
+// {{.Name}}Grain actor instance.
+func New{{.Name}}StreamDispatcher() *{{.Name}}StreamDispatcher {
+	return &{{.Name}}StreamDispatcher{streams: make(map[string]*cluster.ServerStream)}
+}
+
+// Dispatch delivers frame to grain, starting a new handler goroutine for
+// frame.Method() on its first frame and routing subsequent frames for
+// the same method to it. ctx.Respond is called with the handler's next
+// outgoing message, the same request-carries-response leg
+// {{.Name}}GrainClient's bidi call-site helper already assumes.
+func (d *{{.Name}}StreamDispatcher) Dispatch(ctx actor.Context, grain {{.Name}}Grain, frame *cluster.StreamFrame) {
+	d.mu.Lock()
+	stream, ok := d.streams[frame.Method()]
+	if !ok {
+		stream = cluster.NewServerStream(context.Background(), d.bufferSize(frame.Method()))
+		d.streams[frame.Method()] = stream
+		d.start(grain, frame.Method(), stream)
+	}
+	d.mu.Unlock()
+
+	payload, err := frame.UnwrapPayload()
+	if err != nil {
+		ctx.Respond(err)
+		return
+	}
+
+	resp, err := stream.Deliver(payload)
+	if err != nil {
+		ctx.Respond(err)
+		return
+	}
+	respMsg, ok := resp.(proto.Message)
+	if !ok {
+		ctx.Respond(fmt.Errorf("{{.Name}}StreamDispatcher: %s sent a non-proto.Message response", frame.Method()))
+		return
+	}
+	respFrame, err := cluster.NewStreamFrame(frame.Method(), respMsg)
+	if err != nil {
+		ctx.Respond(err)
+		return
+	}
+	ctx.Respond(respFrame)
+}
+
+// start spawns the handler goroutine for method when it is one of this
+// service's dispatchable (bidi) methods; for any other method name no
+// handler goroutine is started.
+func (d *{{.Name}}StreamDispatcher) start(grain {{.Name}}Grain, method string, stream *cluster.ServerStream) {
+	switch method {
+{{- range .Methods}}
+{{- if .IsDispatchable}}
+	case "{{.Name}}":
+		go func() {
+			defer stream.Close()
+			if err := grain.{{.Name}}(stream.Context(), &{{$.Name}}{{.Name}}ServerStreamImpl{ServerStream: stream}); err != nil {
+				slog.Error("{{$.Name}}.{{.Name}} stream handler returned an error", "error", err)
+			}
+		}()
+{{- end}}
+{{- end}}
+	}
+}
+
+// bufferSize returns the ServerStreamBufferSize configured for method via
+// the .proto's MethodOptions, so each method's ServerStream is buffered
+// the way its own client-side {{.Name}}GrainClient call-site helper is.
+func (d *{{.Name}}StreamDispatcher) bufferSize(method string) int32 {
+	switch method {
+{{- range .Methods}}
+{{- if .IsDispatchable}}
+	case "{{.Name}}":
+		return {{.ServerBufferSize}}
+{{- end}}
+{{- end}}
+	}
+	return 0
+}
+{{- end}}
+
+// {{.Name}}GrainClient is the call-site helper generated for {{.Name}}.
+type {{.Name}}GrainClient struct {
+	Identity string
+	cluster  *cluster.Cluster
+}
+
+// Get{{.Name}}GrainClient returns a call-site helper bound to identity.
+func Get{{.Name}}GrainClient(c *cluster.Cluster, identity string) *{{.Name}}GrainClient {
+	return &{{.Name}}GrainClient{Identity: identity, cluster: c}
+}
+{{range .Methods}}
+{{- if not .IsStreaming}}
+
+func (g *{{$.Name}}GrainClient) {{.Name}}(ctx context.Context, req *{{.Input}}) (*{{.Output}}, error) {
+	resp, err := g.cluster.Call(g.Identity, "{{$.Name}}", req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*{{.Output}}), nil
+}
+{{- else if and .IsStreamingClient .IsStreamingServer}}
+
+// {{.Name}} opens a bidi stream to the grain, buffered to
+// {{.ClientBufferSize}} outbound and {{.ServerBufferSize}} inbound; closing
+// ctx stops the caller's side, and the grain-side handler is not notified.
+func (g *{{$.Name}}GrainClient) {{.Name}}(ctx context.Context) ({{$.Name}}{{.Name}}Stream, error) {
+	s, err := g.cluster.OpenStream(ctx, g.Identity, "{{$.Name}}", "{{.Name}}", {{.ClientBufferSize}}, {{.ServerBufferSize}})
+	if err != nil {
+		return nil, err
+	}
+	return &{{$.Name}}{{.Name}}StreamImpl{ClientStream: s}, nil
+}
+{{- else if .IsStreamingServer}}
+
+// {{.Name}} calls the grain once with req and returns a channel, buffered
+// to {{.ServerBufferSize}}, that carries at most that call's one
+// {{.Output}} response and is then closed; further server pushes are not
+// received.
+func (g *{{$.Name}}GrainClient) {{.Name}}(ctx context.Context, req *{{.Input}}) (<-chan *{{.Output}}, error) {
+	raw, err := g.cluster.ServerStreamCall(ctx, g.Identity, "{{$.Name}}", "{{.Name}}", req, {{.ServerBufferSize}})
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan *{{.Output}}, {{.ServerBufferSize}})
+	go func() {
+		defer close(out)
+		for msg := range raw {
+			out <- msg.(*{{.Output}})
+		}
+	}()
+	return out, nil
+}
+{{- else}}
+
+// {{.Name}} sends every {{.Input}} received on in to the grain, buffered
+// to {{.ClientBufferSize}} so a slow grain applies backpressure to the
+// caller, and returns once the grain responds with a final {{.Output}}.
+func (g *{{$.Name}}GrainClient) {{.Name}}(ctx context.Context, in <-chan *{{.Input}}) (*{{.Output}}, error) {
+	boxed := make(chan interface{}, {{.ClientBufferSize}})
+	go func() {
+		defer close(boxed)
+		for msg := range in {
+			boxed <- msg
+		}
+	}()
+
+	resp, err := g.cluster.ClientStreamCall(ctx, g.Identity, "{{$.Name}}", "{{.Name}}", boxed, {{.ClientBufferSize}})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*{{.Output}}), nil
+}
+{{- end}}
+{{- end}}
+{{range .Methods}}
+{{- if .RequiredRoles}}
+
+// {{$.Name}}{{.Name}}RequiredRoles lists the roles declared for {{.Name}}
+// via MethodOptions.required_roles. Neither the generated code nor
+// cluster.authorize checks them.
+var {{$.Name}}{{.Name}}RequiredRoles = []string{ {{range .RequiredRoles}}"{{.}}", {{end}} }
+{{- end}}
+{{- end}}
+`
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestServiceDescExecuteUnary(t *testing.T) {
+	sd := &serviceDesc{
+		Name: "Greeter",
+		Methods: []*methodDesc{
+			{Name: "SayHello", Input: "HelloRequest", Output: "HelloReply"},
+		},
+	}
+
+	out := sd.execute()
+
+	if !strings.Contains(out, "SayHello(ctx context.Context, req *HelloRequest) (*HelloReply, error)") {
+		t.Errorf("expected unary method signature in generated output, got:\n%s", out)
+	}
+	if strings.Contains(out, "Stream") {
+		t.Errorf("unary-only service should not emit any Stream type, got:\n%s", out)
+	}
+}
+
+func TestServiceDescExecuteServerStreaming(t *testing.T) {
+	sd := &serviceDesc{
+		Name: "Greeter",
+		Methods: []*methodDesc{
+			{
+				Name:              "Subscribe",
+				Input:             "SubscribeRequest",
+				Output:            "Event",
+				IsStreamingServer: true,
+				ServerBufferSize:  32,
+			},
+		},
+	}
+
+	out := sd.execute()
+
+	if !strings.Contains(out, "Subscribe(ctx context.Context, req *SubscribeRequest) (<-chan *Event, error)") {
+		t.Errorf("expected server-streaming grain signature, got:\n%s", out)
+	}
+	if !strings.Contains(out, "func (g *GreeterGrainClient) Subscribe(ctx context.Context, req *SubscribeRequest) (<-chan *Event, error)") {
+		t.Errorf("expected server-streaming call-site helper, got:\n%s", out)
+	}
+}
+
+func TestServiceDescExecuteBidiStreaming(t *testing.T) {
+	sd := &serviceDesc{
+		Name: "Greeter",
+		Methods: []*methodDesc{
+			{
+				Name:              "Chat",
+				Input:             "ChatMessage",
+				Output:            "ChatMessage",
+				IsStreamingClient: true,
+				IsStreamingServer: true,
+				ClientBufferSize:  8,
+				ServerBufferSize:  8,
+			},
+		},
+	}
+
+	out := sd.execute()
+
+	if !strings.Contains(out, "type GreeterChatStream interface") {
+		t.Errorf("expected bidi stream interface, got:\n%s", out)
+	}
+	if !strings.Contains(out, "Send(*ChatMessage) error") || !strings.Contains(out, "Recv() (*ChatMessage, error)") {
+		t.Errorf("expected Send/Recv on the bidi stream interface, got:\n%s", out)
+	}
+}
+
+func TestServiceDescExecuteBidiStreamingDispatcher(t *testing.T) {
+	sd := &serviceDesc{
+		Name: "Greeter",
+		Methods: []*methodDesc{
+			{
+				Name:              "Chat",
+				Input:             "ChatMessage",
+				Output:            "ChatMessage",
+				IsStreamingClient: true,
+				IsStreamingServer: true,
+				ClientBufferSize:  8,
+				ServerBufferSize:  8,
+			},
+		},
+	}
+
+	out := sd.execute()
+
+	if !strings.Contains(out, "type GreeterStreamDispatcher struct") {
+		t.Errorf("expected a GreeterStreamDispatcher for a service with a bidi method, got:\n%s", out)
+	}
+	if !strings.Contains(out, "func (d *GreeterStreamDispatcher) Dispatch(ctx actor.Context, grain GreeterGrain, frame *cluster.StreamFrame)") {
+		t.Errorf("expected Dispatch to take the grain and an inbound StreamFrame, got:\n%s", out)
+	}
+	if !strings.Contains(out, `case "Chat":
+		return 8`) {
+		t.Errorf("expected bufferSize to return Chat's ServerBufferSize, got:\n%s", out)
+	}
+	if !strings.Contains(out, "type GreeterChatServerStreamImpl struct") {
+		t.Errorf("expected a grain-side ServerStreamImpl for Chat, got:\n%s", out)
+	}
+}
+
+func TestServiceDescExecuteServerStreamingOnlyHasNoDispatcher(t *testing.T) {
+	sd := &serviceDesc{
+		Name: "Greeter",
+		Methods: []*methodDesc{
+			{
+				Name:              "Subscribe",
+				Input:             "SubscribeRequest",
+				Output:            "Event",
+				IsStreamingServer: true,
+				ServerBufferSize:  32,
+			},
+		},
+	}
+
+	out := sd.execute()
+
+	if strings.Contains(out, "StreamDispatcher") {
+		t.Errorf("server-streaming-only service has no Stream object to dispatch onto, want no dispatcher, got:\n%s", out)
+	}
+}
+
+func TestServiceDescExecuteRequiredRoles(t *testing.T) {
+	sd := &serviceDesc{
+		Name: "Greeter",
+		Methods: []*methodDesc{
+			{Name: "Admin", Input: "AdminRequest", Output: "AdminReply", RequiredRoles: []string{"admin"}},
+		},
+	}
+
+	out := sd.execute()
+
+	if !strings.Contains(out, `var GreeterAdminRequiredRoles = []string{ "admin",  }`) {
+		t.Errorf("expected required-roles annotation, got:\n%s", out)
+	}
+}